@@ -76,3 +76,64 @@ func BenchmarkReplaceAttrComplex(b *testing.B) {
 		ReplaceAttr(groups, attr)
 	}
 }
+
+// BenchmarkReplaceAttrComplexWithKeyMatcher runs the same data through a
+// Redactor with a key matcher configured, which forces the matcher-aware
+// path instead of the cached descriptor path. Comparing this against
+// BenchmarkReplaceAttrComplex shows the cost the type cache saves.
+func BenchmarkReplaceAttrComplexWithKeyMatcher(b *testing.B) {
+	r := New(WithKeyMatcher(func(groups []string, key string) (Action, bool) {
+		return ActionKeep, false
+	}))
+	data := generateComplexData()
+
+	attr := slog.Attr{
+		Key:   "data",
+		Value: slog.AnyValue(data),
+	}
+
+	groups := []string{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ReplaceAttr(groups, attr)
+	}
+}
+
+type PlainFriends struct {
+	ID      int
+	Name    string
+	Friends []string
+}
+
+func generatePlainFriendsData() PlainFriends {
+	var friends []string
+	for i := 0; i < 1000; i++ {
+		friends = append(friends, "Friend"+strconv.Itoa(i))
+	}
+
+	return PlainFriends{
+		ID:      999,
+		Name:    "PlainExample",
+		Friends: friends,
+	}
+}
+
+// BenchmarkReplaceAttrComplexLeafSlice exercises an untagged 1000-element
+// []string field, which the type cache lets ReplaceAttr pass through
+// without copying or re-reflecting a single element.
+func BenchmarkReplaceAttrComplexLeafSlice(b *testing.B) {
+	data := generatePlainFriendsData()
+
+	attr := slog.Attr{
+		Key:   "data",
+		Value: slog.AnyValue(data),
+	}
+
+	groups := []string{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ReplaceAttr(groups, attr)
+	}
+}
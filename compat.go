@@ -0,0 +1,109 @@
+package sentinel
+
+import (
+	"reflect"
+	"sort"
+)
+
+// TagRule decides, given the raw value of some non-`sentinel` tag, whether
+// it claims a field for redaction.
+type TagRule func(tagValue string) (Action, bool)
+
+// Tag returns a TagRule that claims any field carrying the tag, regardless
+// of its value, e.g. for AWS SDK's `sensitive:"true"`.
+func Tag(action Action) TagRule {
+	return func(string) (Action, bool) {
+		return action, true
+	}
+}
+
+// IfEquals returns a TagRule that claims a field only when the tag's value
+// is exactly want, e.g. `json:"-"`.
+func IfEquals(want string, action Action) TagRule {
+	return func(tagValue string) (Action, bool) {
+		if tagValue != want {
+			return ActionKeep, false
+		}
+		return action, true
+	}
+}
+
+type additionalTag struct {
+	name string
+	rule TagRule
+}
+
+// tagResolution is what resolveTag found for a field: either drop it
+// outright, or apply strategy.
+type tagResolution struct {
+	drop     bool
+	strategy Strategy
+}
+
+// WithAdditionalTags lets sentinel honor tags other libraries already use to
+// mark sensitive fields (e.g. AWS SDK's `sensitive:"true"`, or a
+// `json:"-"`-style exclusion), so existing annotations don't need
+// duplicating under `sentinel`. Configured tags are checked in priority
+// order - alphabetically by tag name, for determinism - before the
+// `sentinel` tag, which is always the final fallback. That alphabetical
+// order holds across multiple WithAdditionalTags/preset calls too (e.g.
+// New(AWSCompat(), JSONDashCompat()) and New(JSONDashCompat(), AWSCompat())
+// check tags in the same order): New sorts r.additionalTags once, globally,
+// after every Option has run.
+func WithAdditionalTags(tags map[string]TagRule) Option {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return func(r *Redactor) {
+		for _, name := range names {
+			r.additionalTags = append(r.additionalTags, additionalTag{name: name, rule: tags[name]})
+		}
+	}
+}
+
+// AWSCompat honors the AWS SDK's `sensitive:"true"` convention for marking
+// fields that shouldn't appear in logs or prettified output.
+func AWSCompat() Option {
+	return WithAdditionalTags(map[string]TagRule{
+		"sensitive": Tag(ActionRedact),
+	})
+}
+
+// JSONDashCompat drops any field tagged `json:"-"`, matching encoding/json's
+// own convention for excluding a field from serialization.
+func JSONDashCompat() Option {
+	return WithAdditionalTags(map[string]TagRule{
+		"json": IfEquals("-", ActionDrop),
+	})
+}
+
+// resolveTag evaluates r's additional tags in priority order and then falls
+// back to the `sentinel` tag, reporting what to do with sf. ok is false when
+// nothing claimed the field, in which case field/key matchers and normal
+// recursion still apply.
+func (r *Redactor) resolveTag(sf reflect.StructField) (res tagResolution, ok bool) {
+	for _, t := range r.additionalTags {
+		tagValue, present := sf.Tag.Lookup(t.name)
+		if !present {
+			continue
+		}
+		if action, matched := t.rule(tagValue); matched {
+			if action == ActionDrop {
+				return tagResolution{drop: true}, true
+			}
+			return tagResolution{strategy: actionStrategy(action)}, true
+		}
+	}
+
+	if tagValue := sf.Tag.Get(r.tag); tagValue != "" {
+		if tagValue == dropTag {
+			return tagResolution{drop: true}, true
+		}
+		return tagResolution{strategy: lookupStrategy(tagValue)}, true
+	}
+
+	return tagResolution{}, false
+}
@@ -0,0 +1,25 @@
+package sentinel
+
+import "testing"
+
+// TestAdditionalTagsOrderIsDeterministicAcrossPresets checks that combining
+// presets in either order produces the same alphabetical-by-tag-name
+// priority order, since New is documented to sort globally regardless of
+// call order.
+func TestAdditionalTagsOrderIsDeterministicAcrossPresets(t *testing.T) {
+	a := New(AWSCompat(), JSONDashCompat())
+	b := New(JSONDashCompat(), AWSCompat())
+
+	wantNames := []string{"json", "sensitive"}
+
+	for _, r := range []*Redactor{a, b} {
+		if len(r.additionalTags) != len(wantNames) {
+			t.Fatalf("got %d additionalTags, want %d", len(r.additionalTags), len(wantNames))
+		}
+		for i, name := range wantNames {
+			if r.additionalTags[i].name != name {
+				t.Fatalf("position %d: got %q, want %q", i, r.additionalTags[i].name, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package sentinel
+
+import "reflect"
+
+// cycleKey identifies a pointer we've already walked. Keying on the address
+// alone is unsound: two distinct values can share an address at different
+// types (e.g. an embedded struct pointer and something reached through an
+// unsafe.Pointer conversion), and collapsing them hides real data or, worse,
+// treats an unrelated value as already visited.
+type cycleKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// cyclePlaceholder is emitted in place of a pointer's contents the second
+// time it's reached, so that cyclic structures terminate without silently
+// re-emitting a value whose sensitive fields were already scrubbed once (or
+// worse, emitting it raw, as a naive "return the original interface" would).
+const cyclePlaceholder = "<cycle>"
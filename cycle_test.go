@@ -0,0 +1,52 @@
+package sentinel
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// TestReplaceAttrInterfacePointer guards against a panic in the
+// pointer/interface unwrap loop: reflect.Value.Pointer() is only valid for
+// Pointer kind, not Interface kind.
+func TestReplaceAttrInterfacePointer(t *testing.T) {
+	var x interface{} = "hello"
+	attr := slog.Attr{Key: "data", Value: slog.AnyValue(&x)}
+
+	out := ReplaceAttr(nil, attr)
+
+	if out.Value.Any() != "hello" {
+		t.Fatalf("got %#v, want \"hello\"", out.Value.Any())
+	}
+}
+
+type cycleNode struct {
+	Name   string
+	Secret string `sentinel:"true"`
+	Next   *cycleNode
+}
+
+// TestReplaceAttrCycleTerminates checks that a self-referential pointer
+// graph terminates instead of recursing forever, and that the second
+// occurrence of a visited pointer doesn't leak its unredacted contents.
+func TestReplaceAttrCycleTerminates(t *testing.T) {
+	a := &cycleNode{Name: "a", Secret: "s1"}
+	b := &cycleNode{Name: "b", Secret: "s2"}
+	a.Next = b
+	b.Next = a
+
+	attr := slog.Attr{Key: "data", Value: slog.AnyValue(a)}
+	out := ReplaceAttr(nil, attr) // must return, not hang
+
+	group := out.Value.Group()
+	var next slog.Value
+	for _, sub := range group {
+		if sub.Key == "Next" {
+			next = sub.Value
+		}
+	}
+	for _, sub := range next.Group() {
+		if sub.Key == "Next" && sub.Value.Any() != cyclePlaceholder {
+			t.Fatalf("expected cycle placeholder, got %#v", sub.Value.Any())
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package sentinel
+
+import (
+	"log/slog"
+	"testing"
+)
+
+type groupValuer struct {
+	attrs []slog.Attr
+}
+
+func (g groupValuer) LogValue() slog.Value {
+	return slog.GroupValue(g.attrs...)
+}
+
+// TestProcessValueGroupPathIntegrity guards against path slice aliasing
+// across sibling attrs in a KindGroup value: a key matcher that retains the
+// groups slice it was called with must see each sibling's own path, not one
+// clobbered by whichever sibling ran last.
+func TestProcessValueGroupPathIntegrity(t *testing.T) {
+	var seen [][]string
+	r := New(WithKeyMatcher(func(groups []string, key string) (Action, bool) {
+		if key == "leaf" {
+			seen = append(seen, append([]string(nil), groups...))
+		}
+		return ActionKeep, false
+	}))
+
+	outer := slog.GroupValue(
+		slog.Group("first", slog.String("leaf", "a")),
+		slog.Group("second", slog.String("leaf", "b")),
+	)
+	attr := slog.Attr{Key: "outer", Value: outer}
+	_ = r.ReplaceAttr(nil, attr)
+
+	want := [][]string{{"first"}, {"second"}}
+	if len(seen) != len(want) {
+		t.Fatalf("expected %d recorded paths, got %v", len(want), seen)
+	}
+	for i, w := range want {
+		if len(seen[i]) != len(w) {
+			t.Fatalf("path %d: got %v, want %v", i, seen[i], w)
+		}
+		for j := range w {
+			if seen[i][j] != w[j] {
+				t.Fatalf("path %d: got %v, want %v", i, seen[i], w)
+			}
+		}
+	}
+}
+
+// TestProcessValueGroupMaskUsesReflectValue checks that a key matcher
+// claiming ActionMask/ActionHash inside a Group-kind value (e.g. from a
+// LogValuer) applies the real strategy instead of nulling the value out.
+func TestProcessValueGroupMaskUsesReflectValue(t *testing.T) {
+	r := New(WithKeyMatcher(func(groups []string, key string) (Action, bool) {
+		if key == "password" {
+			return ActionMask, true
+		}
+		return ActionKeep, false
+	}))
+
+	v := groupValuer{attrs: []slog.Attr{
+		slog.String("username", "alice"),
+		slog.String("password", "hunter2"),
+	}}
+	attr := slog.Attr{Key: "data", Value: slog.AnyValue(v)}
+	out := r.ReplaceAttr(nil, attr)
+
+	got := attrsByKey(out.Value)
+	if got["password"] != "***" {
+		t.Fatalf("password: got %#v, want \"***\"", got["password"])
+	}
+	if got["username"] != "alice" {
+		t.Fatalf("username: got %#v, want \"alice\"", got["username"])
+	}
+}
@@ -0,0 +1,68 @@
+package sentinel
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Handler wraps an inner slog.Handler and runs every attribute it sees
+// through a Redactor, including attrs bound ahead of time with Logger.With
+// and group prefixes added with Logger.WithGroup. Plain ReplaceAttr only
+// sees attrs passed at the log call site and, depending on the handler, may
+// never see attrs captured by With; Handler fixes both gaps and keeps the
+// group path so key matchers can see the full dotted path an attribute
+// lives under (e.g. "req.headers.authorization").
+type Handler struct {
+	inner    slog.Handler
+	redactor *Redactor
+	groups   []string
+}
+
+// NewHandler wraps inner so that every attribute reaching it is first run
+// through the Redactor built from opts.
+func NewHandler(inner slog.Handler, opts ...Option) slog.Handler {
+	return &Handler{inner: inner, redactor: New(opts...)}
+}
+
+// Enabled delegates to the inner handler unchanged.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle redacts the record's attrs, then delegates to the inner handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	newRecord := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		if pa := h.redactor.processAttr(h.groups, a); pa.Key != "" {
+			newRecord.AddAttrs(pa)
+		}
+		return true
+	})
+	return h.inner.Handle(ctx, newRecord)
+}
+
+// WithAttrs redacts attrs bound via Logger.With before passing them to the
+// inner handler, so that pre-bound sensitive data can't bypass ReplaceAttr.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	processed := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if pa := h.redactor.processAttr(h.groups, a); pa.Key != "" {
+			processed = append(processed, pa)
+		}
+	}
+	return &Handler{
+		inner:    h.inner.WithAttrs(processed),
+		redactor: h.redactor,
+		groups:   h.groups,
+	}
+}
+
+// WithGroup extends the tracked group path so that later key-matcher checks
+// see the full dotted path, then delegates to the inner handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{
+		inner:    h.inner.WithGroup(name),
+		redactor: h.redactor,
+		groups:   append(append([]string{}, h.groups...), name),
+	}
+}
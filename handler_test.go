@@ -0,0 +1,84 @@
+package sentinel
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func newTestHandler(t *testing.T, buf *bytes.Buffer, opts ...Option) slog.Handler {
+	t.Helper()
+	return NewHandler(slog.NewJSONHandler(buf, nil), opts...)
+}
+
+// TestHandlerWithAttrsScrubsBoundValues checks that a value bound via
+// Logger.With is redacted before it ever reaches the inner handler, not just
+// values passed at the log call site.
+func TestHandlerWithAttrsScrubsBoundValues(t *testing.T) {
+	var buf bytes.Buffer
+	h := newTestHandler(t, &buf, WithKeyMatcher(func(groups []string, key string) (Action, bool) {
+		if key == "password" {
+			return ActionRedact, true
+		}
+		return ActionKeep, false
+	}))
+
+	logger := slog.New(h).With("password", "hunter2")
+	logger.Info("login")
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["password"] != "" {
+		t.Fatalf("password should have been redacted, got %v", out["password"])
+	}
+}
+
+// TestHandlerWithGroupTracksDottedPath checks that Handler.WithGroup extends
+// the path a key matcher sees, so a matcher can target a key nested under a
+// specific group prefix.
+func TestHandlerWithGroupTracksDottedPath(t *testing.T) {
+	var buf bytes.Buffer
+	var gotGroups []string
+	h := newTestHandler(t, &buf, WithKeyMatcher(func(groups []string, key string) (Action, bool) {
+		if key == "authorization" {
+			gotGroups = append([]string(nil), groups...)
+			return ActionRedact, true
+		}
+		return ActionKeep, false
+	}))
+
+	logger := slog.New(h).WithGroup("req").WithGroup("headers")
+	logger.Info("request", "authorization", "Bearer secret")
+
+	if len(gotGroups) != 2 || gotGroups[0] != "req" || gotGroups[1] != "headers" {
+		t.Fatalf("got groups %v, want [req headers]", gotGroups)
+	}
+}
+
+// TestHandlerHandleDropsAttr checks that ActionDrop removes the attribute
+// from the record entirely instead of leaving a zeroed placeholder.
+func TestHandlerHandleDropsAttr(t *testing.T) {
+	var buf bytes.Buffer
+	h := newTestHandler(t, &buf, WithKeyMatcher(func(groups []string, key string) (Action, bool) {
+		if key == "secret" {
+			return ActionDrop, true
+		}
+		return ActionKeep, false
+	}))
+
+	slog.New(h).Info("msg", "secret", "x", "kept", "y")
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if _, ok := out["secret"]; ok {
+		t.Fatalf("secret should have been dropped, got %v", out)
+	}
+	if out["kept"] != "y" {
+		t.Fatalf("kept: got %v, want y", out["kept"])
+	}
+}
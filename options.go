@@ -0,0 +1,143 @@
+package sentinel
+
+import (
+	"log/slog"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Action tells a Redactor what to do with a value that a matcher has
+// claimed responsibility for.
+type Action int
+
+const (
+	// ActionKeep leaves the value untouched and lets normal recursion
+	// continue into it, as if no matcher had claimed it.
+	ActionKeep Action = iota
+	// ActionRedact zeroes the value.
+	ActionRedact
+	// ActionMask replaces strings with a placeholder and zeroes everything
+	// else.
+	ActionMask
+	// ActionHash replaces a string value with a stable hex SHA-256 prefix.
+	ActionHash
+	// ActionDrop omits the value's attribute entirely.
+	ActionDrop
+)
+
+// FieldMatcherFunc decides whether a struct field, identified by its
+// reflect.StructField and the chain of field names leading to it, should be
+// redacted. The second return value reports whether the matcher claims the
+// field at all; when false, Action is ignored and normal processing (tag
+// lookup, then recursion) applies.
+type FieldMatcherFunc func(sf reflect.StructField, path []string) (Action, bool)
+
+// KeyMatcherFunc decides whether an attribute, identified by the slog group
+// path it lives under and its key, should be redacted. Unlike
+// FieldMatcherFunc it doesn't require owning the source type, so it also
+// matches map keys and third-party structs.
+type KeyMatcherFunc func(groups []string, key string) (Action, bool)
+
+// TypeHandlerFunc renders a value of a specific type directly to a
+// slog.Value, bypassing the default struct/slice/map recursion. Useful for
+// third-party types that need special handling, e.g. scrubbing an
+// *http.Request's headers.
+type TypeHandlerFunc func(v reflect.Value) slog.Value
+
+// Redactor holds a configured redaction pipeline. The zero value is not
+// usable; construct one with New.
+type Redactor struct {
+	tag              string
+	fieldMatcher     FieldMatcherFunc
+	keyMatcher       KeyMatcherFunc
+	typeHandlers     map[reflect.Type]TypeHandlerFunc
+	additionalTags   []additionalTag
+	unsafeUnexported bool
+
+	// typeCache holds *typeDescriptor values keyed by reflect.Type, so that
+	// struct layouts are only walked once. It's only consulted when no
+	// field/key matcher is configured, since those can vary by call site in
+	// ways a type-keyed cache can't capture.
+	typeCache sync.Map
+}
+
+// Option configures a Redactor built with New.
+type Option func(*Redactor)
+
+// WithTag overrides the struct tag name Redactor looks for (default
+// "sentinel").
+func WithTag(tag string) Option {
+	return func(r *Redactor) {
+		r.tag = tag
+	}
+}
+
+// WithFieldMatcher adds a matcher consulted for every struct field that
+// isn't already claimed by a tag.
+func WithFieldMatcher(fn FieldMatcherFunc) Option {
+	return func(r *Redactor) {
+		r.fieldMatcher = fn
+	}
+}
+
+// WithKeyMatcher adds a matcher consulted for every attribute key, whether
+// it comes from a struct field, a map key, or a log-call-site Attr.
+func WithKeyMatcher(fn KeyMatcherFunc) Option {
+	return func(r *Redactor) {
+		r.keyMatcher = fn
+	}
+}
+
+// WithTypeHandler registers a custom renderer for a specific type, checked
+// before the default struct/slice/map recursion.
+func WithTypeHandler(t reflect.Type, fn TypeHandlerFunc) Option {
+	return func(r *Redactor) {
+		if r.typeHandlers == nil {
+			r.typeHandlers = make(map[reflect.Type]TypeHandlerFunc)
+		}
+		r.typeHandlers[t] = fn
+	}
+}
+
+// WithUnsafeUnexported lets a Redactor reach into unexported struct fields
+// using unsafe.Pointer, instead of silently leaving them untouched because
+// reflect won't Interface() them. Off by default: it's an explicit opt-in
+// because it reads memory reflect would normally refuse to expose.
+func WithUnsafeUnexported(enabled bool) Option {
+	return func(r *Redactor) {
+		r.unsafeUnexported = enabled
+	}
+}
+
+// New builds a Redactor from the given options. Without WithTag, it looks
+// for the `sentinel` tag, matching the package-level ReplaceAttr.
+func New(opts ...Option) *Redactor {
+	r := &Redactor{tag: "sentinel"}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	// Re-sort globally, alphabetically by tag name, so that priority order
+	// is deterministic regardless of how many WithAdditionalTags/preset
+	// calls contributed to it or in what order (see WithAdditionalTags).
+	sort.SliceStable(r.additionalTags, func(i, j int) bool {
+		return r.additionalTags[i].name < r.additionalTags[j].name
+	})
+
+	return r
+}
+
+// actionStrategy maps a claimed Action to the Strategy that implements it.
+// ActionDrop and ActionKeep are handled by the caller, since they aren't
+// plain value transforms.
+func actionStrategy(a Action) Strategy {
+	switch a {
+	case ActionMask:
+		return mask("***")
+	case ActionHash:
+		return hashValue
+	default:
+		return redact
+	}
+}
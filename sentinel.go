@@ -3,38 +3,106 @@ package sentinel
 import (
 	"log/slog"
 	"reflect"
+	"strings"
 )
 
-// ReplaceAttr processes each attribute and zeroes out any fields marked with the `sentinel` tag.
+// defaultRedactor backs the package-level ReplaceAttr and looks for the
+// plain `sentinel` tag, matching this package's original behavior.
+var defaultRedactor = New()
+
+// ReplaceAttr processes each attribute and redacts any fields marked with a
+// `sentinel` tag, according to the strategy named by the tag value (see
+// RegisterStrategy). A bare non-empty value that isn't a known strategy name
+// falls back to zeroing the field.
+//
+// Once a struct has a tagged/matched field anywhere in it, that struct is no
+// longer logged as a copy of its own type: it's rebuilt as a slog.Group of
+// per-field attrs (see processStruct's doc for why, and the caveat about
+// custom MarshalJSON/String methods).
+//
+// For matcher- or type-handler-based redaction, build a *Redactor with New
+// and use its ReplaceAttr method instead.
 func ReplaceAttr(groups []string, a slog.Attr) slog.Attr {
-	a.Value = processValue(a.Value, make(map[uintptr]bool))
+	return defaultRedactor.ReplaceAttr(groups, a)
+}
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr implementation that
+// applies r's configured tag, matchers, and type handlers.
+func (r *Redactor) ReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	return r.processAttr(groups, a)
+}
+
+// processAttr applies r's pipeline to a single attr, including a key-matcher
+// check against the attr's own key, not just its contents. Handler reuses
+// this so that attrs bound via Logger.With get identical treatment to attrs
+// passed at the log call site. Returning a zero slog.Attr (as done for
+// ActionDrop) is slog's own convention for omitting an attribute.
+func (r *Redactor) processAttr(groups []string, a slog.Attr) slog.Attr {
+	if action, matched := r.matchKey(groups, a.Key); matched && action != ActionKeep {
+		if action == ActionDrop {
+			return slog.Attr{}
+		}
+		rv := reflect.ValueOf(a.Value.Any())
+		if !rv.IsValid() {
+			a.Value = slog.AnyValue(nil)
+			return a
+		}
+		a.Value = slog.AnyValue(actionStrategy(action)(rv).Interface())
+		return a
+	}
+	a.Value = r.processValue(a.Value, groups, make(map[cycleKey]bool))
 	return a
 }
 
 // processValue recursively processes a slog.Value, handling different kinds appropriately.
-func processValue(v slog.Value, visited map[uintptr]bool) slog.Value {
+func (r *Redactor) processValue(v slog.Value, path []string, visited map[cycleKey]bool) slog.Value {
 	switch v.Kind() {
 	case slog.KindAny:
-		return processAny(v.Any(), visited)
+		return r.processAny(v.Any(), path, visited)
 	case slog.KindGroup:
 		// Process each Attr in the group
 		attrs := v.Group()
-		for i, attr := range attrs {
-			attrs[i].Value = processValue(attr.Value, visited)
+		out := make([]slog.Attr, 0, len(attrs))
+		for _, attr := range attrs {
+			if action, ok := r.matchKey(path, attr.Key); ok {
+				if action == ActionDrop {
+					continue
+				}
+				if action != ActionKeep {
+					rv := reflect.ValueOf(attr.Value.Any())
+					if !rv.IsValid() {
+						attr.Value = slog.AnyValue(nil)
+					} else {
+						attr.Value = slog.AnyValue(actionStrategy(action)(rv).Interface())
+					}
+					out = append(out, attr)
+					continue
+				}
+			}
+			attr.Value = r.processValue(attr.Value, append(append([]string{}, path...), attr.Key), visited)
+			out = append(out, attr)
 		}
-		return slog.GroupValue(attrs...)
+		return slog.GroupValue(out...)
 	case slog.KindLogValuer:
 		// Evaluate the LogValuer and process the resulting Value
 		evaluated := v.LogValuer().LogValue()
-		return processValue(evaluated, visited)
+		return r.processValue(evaluated, path, visited)
 	default:
 		// For other kinds, return the value as is
 		return v
 	}
 }
 
+// matchKey consults the configured key matcher, if any.
+func (r *Redactor) matchKey(groups []string, key string) (Action, bool) {
+	if r.keyMatcher == nil {
+		return ActionKeep, false
+	}
+	return r.keyMatcher(groups, key)
+}
+
 // processAny handles values of KindAny, which can be any Go value.
-func processAny(val interface{}, visited map[uintptr]bool) slog.Value {
+func (r *Redactor) processAny(val interface{}, path []string, visited map[cycleKey]bool) slog.Value {
 	if val == nil {
 		return slog.AnyValue(nil)
 	}
@@ -42,7 +110,7 @@ func processAny(val interface{}, visited map[uintptr]bool) slog.Value {
 	// Check if val implements slog.LogValuer
 	if valuer, ok := val.(slog.LogValuer); ok {
 		evaluated := valuer.LogValue()
-		return processValue(evaluated, visited)
+		return r.processValue(evaluated, path, visited)
 	}
 
 	rv := reflect.ValueOf(val)
@@ -53,85 +121,197 @@ func processAny(val interface{}, visited map[uintptr]bool) slog.Value {
 			return slog.AnyValue(nil)
 		}
 
-		// Cycle detection
-		addr := rv.Pointer()
-		if visited[addr] {
-			return slog.AnyValue(rv.Interface())
+		// Cycle detection: only Pointer values have an address; an
+		// Interface value's .Pointer() panics, and interfaces have no
+		// identity of their own to cycle on anyway (the concrete pointer
+		// they hold, if any, is caught on the next loop iteration).
+		if rv.Kind() == reflect.Pointer {
+			key := cycleKey{addr: rv.Pointer(), typ: rv.Type()}
+			if visited[key] {
+				return slog.StringValue(cyclePlaceholder)
+			}
+			visited[key] = true
 		}
-		visited[addr] = true
 
 		// Check if rv.Interface() implements slog.LogValuer
 		if valuer, ok := rv.Interface().(slog.LogValuer); ok {
 			evaluated := valuer.LogValue()
-			return processValue(evaluated, visited)
+			return r.processValue(evaluated, path, visited)
 		}
 
 		rv = rv.Elem()
 	}
 
+	if handler, ok := r.typeHandlers[rv.Type()]; ok {
+		return handler(rv)
+	}
+
 	switch rv.Kind() {
 	case reflect.Struct:
-		return processStruct(rv, visited)
+		return r.processStruct(rv, path, visited)
 	case reflect.Slice, reflect.Array:
-		return processSliceOrArray(rv, visited)
+		return r.processSliceOrArray(rv, path, visited)
 	case reflect.Map:
-		return processMap(rv, visited)
+		return r.processMap(rv, path, visited)
 	case reflect.Pointer:
 		// Should not reach here due to earlier handling, but included for completeness
-		return processPointer(rv, visited)
+		return r.processPointer(rv, path, visited)
 	default:
 		// For basic types, return the value as is
 		return slog.AnyValue(rv.Interface())
 	}
 }
 
-// processStruct processes struct types, zeroing out sensitive fields.
-func processStruct(rv reflect.Value, visited map[uintptr]bool) slog.Value {
-	rt := rv.Type()
+// attrKey picks the slog.Attr key to emit for a struct field: the field's
+// `json` tag name when it has one (matching how the struct would already
+// serialize via encoding/json), falling back to the Go field name.
+func attrKey(sf reflect.StructField) string {
+	if jsonTag, ok := sf.Tag.Lookup("json"); ok {
+		name := jsonTag
+		if i := strings.IndexByte(jsonTag, ','); i >= 0 {
+			name = jsonTag[:i]
+		}
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return sf.Name
+}
 
-	// Create a copy of the original struct
-	newStruct := reflect.New(rt).Elem()
-	newStruct.Set(rv)
+// processStruct processes struct types, applying the configured strategy to
+// any field carrying a `sentinel` tag or claimed by a field/key matcher, and
+// recursing into the rest. The result is built as a slog.GroupValue of
+// per-field attrs, rather than a copy of the original struct, so that a
+// "drop" strategy can omit an attribute entirely instead of merely zeroing
+// it.
+//
+// This is a deliberate output-schema change from the original
+// implementation, which logged a copy of the concrete struct type (so
+// encoding/json, a custom MarshalJSON, or a Stringer drove the final
+// rendering). As soon as a struct has a tagged/matched field, every field in
+// it is now emitted as its own slog.Attr, keyed by attrKey. This keeps JSON
+// handler output equivalent in the common case (no json tags, no custom
+// marshaler), but a struct that relies on a custom MarshalJSON/String method
+// for non-sensitive fields will see that method bypassed once any field is
+// redacted. If that matters for a given type, write a type handler with
+// WithTypeHandler instead of relying on the struct/field tag path.
+//
+// Without field/key matchers, this delegates to processStructCached, which
+// consults the per-type descriptor cache instead of re-walking tags.
+func (r *Redactor) processStruct(rv reflect.Value, path []string, visited map[cycleKey]bool) slog.Value {
+	if r.fieldMatcher == nil && r.keyMatcher == nil {
+		return r.processStructCached(rv, path, visited)
+	}
+
+	rt := rv.Type()
+	attrs := make([]slog.Attr, 0, rv.NumField())
 
 	for i := 0; i < rv.NumField(); i++ {
 		structField := rt.Field(i)
-		newField := newStruct.Field(i)
+		field := rv.Field(i)
+
+		if !field.CanInterface() {
+			accessible, ok := r.unexportedValue(field)
+			if !ok {
+				// Cannot access unexported fields unless WithUnsafeUnexported
+				// is set and the field is addressable.
+				continue
+			}
+			field = accessible
+		}
 
-		// Check if the field is exported
-		if !newField.CanInterface() {
-			// Cannot access unexported fields
+		// Tags (the configured additional ones, then `sentinel`) always win.
+		if res, matched := r.resolveTag(structField); matched {
+			if res.drop {
+				continue
+			}
+			attrs = append(attrs, slog.Any(attrKey(structField), res.strategy(field).Interface()))
 			continue
 		}
 
-		// Check for the 'sentinel' tag
-		if structField.Tag.Get("sentinel") != "" {
-			if newField.CanSet() {
-				// Zero out the sensitive field
-				zeroValue := reflect.Zero(newField.Type())
-				newField.Set(zeroValue)
+		// Then the field matcher, which sees the StructField itself.
+		if r.fieldMatcher != nil {
+			if action, ok := r.fieldMatcher(structField, path); ok && action != ActionKeep {
+				if action == ActionDrop {
+					continue
+				}
+				attrs = append(attrs, slog.Any(attrKey(structField), actionStrategy(action)(field).Interface()))
+				continue
 			}
-		} else {
-			// Recursively process the field
-			fieldValue := newField.Interface()
-			processedValue := processAny(fieldValue, visited)
-			newValue := reflect.ValueOf(processedValue.Any())
-
-			// Ensure type compatibility and that the field is settable
-			if newValue.Type().AssignableTo(structField.Type) && newField.CanSet() {
-				newField.Set(newValue)
+		}
+
+		// Then the key matcher, which only sees the name.
+		if action, ok := r.matchKey(path, structField.Name); ok && action != ActionKeep {
+			if action == ActionDrop {
+				continue
+			}
+			attrs = append(attrs, slog.Any(attrKey(structField), actionStrategy(action)(field).Interface()))
+			continue
+		}
+
+		// Recursively process the field
+		fieldPath := append(append([]string{}, path...), structField.Name)
+		processedValue := r.processAny(field.Interface(), fieldPath, visited)
+		attrs = append(attrs, slog.Attr{Key: attrKey(structField), Value: processedValue})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// processStructCached is the matcher-free fast path: it uses the cached
+// typeDescriptor to skip tag parsing, copies leaf fields through untouched,
+// and short-circuits entirely when the whole type is a leaf.
+func (r *Redactor) processStructCached(rv reflect.Value, path []string, visited map[cycleKey]bool) slog.Value {
+	t := rv.Type()
+	d := r.descriptorFor(t)
+	if d.isLeafType {
+		return slog.AnyValue(rv.Interface())
+	}
+
+	attrs := make([]slog.Attr, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := rv.Field(i)
+		if !field.CanInterface() {
+			accessible, ok := r.unexportedValue(field)
+			if !ok {
+				continue
 			}
+			field = accessible
 		}
+
+		if containsIndex(d.dropFieldIndexes, i) {
+			continue
+		}
+		if si := sensitiveIndex(d.sensitiveFieldIndexes, i); si >= 0 {
+			attrs = append(attrs, slog.Any(attrKey(sf), d.strategies[si](field).Interface()))
+			continue
+		}
+		if containsIndex(d.recurseFieldIndexes, i) {
+			fieldPath := append(append([]string{}, path...), sf.Name)
+			attrs = append(attrs, slog.Attr{Key: attrKey(sf), Value: r.processAny(field.Interface(), fieldPath, visited)})
+			continue
+		}
+
+		// Leaf field: no tag, and its type can't hold sensitive data.
+		attrs = append(attrs, slog.Any(attrKey(sf), field.Interface()))
 	}
 
-	return slog.AnyValue(newStruct.Interface())
+	return slog.GroupValue(attrs...)
 }
 
 // processSliceOrArray processes slices and arrays, recursively processing each element.
-func processSliceOrArray(rv reflect.Value, visited map[uintptr]bool) slog.Value {
+func (r *Redactor) processSliceOrArray(rv reflect.Value, path []string, visited map[cycleKey]bool) slog.Value {
 	if rv.IsNil() {
 		return slog.AnyValue(nil)
 	}
 
+	if r.fieldMatcher == nil && r.keyMatcher == nil && r.typeIsLeaf(rv.Type().Elem()) {
+		// No element can hold sensitive data: skip the copy-and-reflect
+		// loop entirely, even for a large slice.
+		return slog.AnyValue(rv.Interface())
+	}
+
 	length := rv.Len()
 	newSlice := reflect.MakeSlice(rv.Type(), length, length)
 	reflect.Copy(newSlice, rv)
@@ -144,7 +324,7 @@ func processSliceOrArray(rv reflect.Value, visited map[uintptr]bool) slog.Value
 
 		// Recursively process the element
 		elementValue := element.Interface()
-		processedElement := processAny(elementValue, visited)
+		processedElement := r.processAny(elementValue, path, visited)
 		newValue := reflect.ValueOf(processedElement.Any())
 
 		// Ensure type compatibility
@@ -157,7 +337,7 @@ func processSliceOrArray(rv reflect.Value, visited map[uintptr]bool) slog.Value
 }
 
 // processMap processes map types, recursively processing keys and values.
-func processMap(rv reflect.Value, visited map[uintptr]bool) slog.Value {
+func (r *Redactor) processMap(rv reflect.Value, path []string, visited map[cycleKey]bool) slog.Value {
 	if rv.IsNil() {
 		return slog.AnyValue(nil)
 	}
@@ -173,17 +353,26 @@ func processMap(rv reflect.Value, visited map[uintptr]bool) slog.Value {
 		var processedKey reflect.Value
 		if key.CanInterface() {
 			keyValue := key.Interface()
-			processedKeyVal := processAny(keyValue, visited)
+			processedKeyVal := r.processAny(keyValue, path, visited)
 			processedKey = reflect.ValueOf(processedKeyVal.Any())
 		} else {
 			processedKey = key
 		}
 
-		// Process value
+		// Process value, honoring the key matcher when the map key is a string.
 		var processedValue reflect.Value
 		if value.CanInterface() {
+			if keyStr, ok := key.Interface().(string); ok {
+				if action, matched := r.matchKey(path, keyStr); matched && action != ActionKeep {
+					if action == ActionDrop {
+						continue
+					}
+					newMap.SetMapIndex(processedKey, actionStrategy(action)(value))
+					continue
+				}
+			}
 			valueValue := value.Interface()
-			processedValueVal := processAny(valueValue, visited)
+			processedValueVal := r.processAny(valueValue, path, visited)
 			processedValue = reflect.ValueOf(processedValueVal.Any())
 		} else {
 			processedValue = value
@@ -199,19 +388,19 @@ func processMap(rv reflect.Value, visited map[uintptr]bool) slog.Value {
 }
 
 // processPointer processes pointer types, handling cycles and recursion.
-func processPointer(rv reflect.Value, visited map[uintptr]bool) slog.Value {
+func (r *Redactor) processPointer(rv reflect.Value, path []string, visited map[cycleKey]bool) slog.Value {
 	if rv.IsNil() {
 		return slog.AnyValue(nil)
 	}
 
 	// Cycle detection
-	addr := rv.Pointer()
-	if visited[addr] {
-		return slog.AnyValue(rv.Interface())
+	key := cycleKey{addr: rv.Pointer(), typ: rv.Type()}
+	if visited[key] {
+		return slog.StringValue(cyclePlaceholder)
 	}
-	visited[addr] = true
+	visited[key] = true
 
-	processedValue := processAny(rv.Elem().Interface(), visited)
+	processedValue := r.processAny(rv.Elem().Interface(), path, visited)
 	newPtr := reflect.New(rv.Type().Elem())
 	newValue := reflect.ValueOf(processedValue.Any())
 
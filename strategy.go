@@ -0,0 +1,109 @@
+package sentinel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Strategy redacts a single tagged field's value, returning the replacement
+// to store in its place. The returned value must be assignable to v's type.
+type Strategy func(v reflect.Value) reflect.Value
+
+// dropTag is the `sentinel` tag value that removes the attribute entirely
+// instead of replacing it with a redacted value.
+const dropTag = "drop"
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]Strategy{
+		"redact":  redact,
+		"mask":    mask("***"),
+		"partial": partial(2),
+		"hash":    hashValue,
+	}
+)
+
+// RegisterStrategy registers a named redaction strategy that can be selected
+// with a `sentinel:"name"` struct tag. Registering a name that already
+// exists, including a built-in one, replaces it.
+func RegisterStrategy(name string, fn Strategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[name] = fn
+}
+
+// lookupStrategy resolves a `sentinel` tag value to a Strategy. The value
+// may carry a "name=arg" suffix for built-ins that accept one (mask,
+// partial); other strategies ignore any suffix. Unknown names fall back to
+// redact, so that pre-existing `sentinel:"true"`-style tags keep zeroing the
+// field.
+func lookupStrategy(tagValue string) Strategy {
+	name, arg := tagValue, ""
+	if i := strings.IndexByte(tagValue, '='); i >= 0 {
+		name, arg = tagValue[:i], tagValue[i+1:]
+	}
+
+	if name == "mask" && arg != "" {
+		return mask(arg)
+	}
+	if name == "partial" && arg != "" {
+		if n, err := strconv.Atoi(arg); err == nil && n >= 0 {
+			return partial(n)
+		}
+	}
+
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	if fn, ok := strategies[name]; ok {
+		return fn
+	}
+	return redact
+}
+
+// redact is the default strategy: it zeroes the field's value.
+func redact(v reflect.Value) reflect.Value {
+	return reflect.Zero(v.Type())
+}
+
+// mask returns a strategy that replaces strings with placeholder and leaves
+// everything else zeroed.
+func mask(placeholder string) Strategy {
+	return func(v reflect.Value) reflect.Value {
+		if v.Kind() == reflect.String {
+			return reflect.ValueOf(placeholder).Convert(v.Type())
+		}
+		return reflect.Zero(v.Type())
+	}
+}
+
+// partial keeps the first and last n runes of a string and masks the rest,
+// e.g. partial(2) turns "alice@example.com" into "al***om". Strings no
+// longer than 2*n are masked entirely; non-string values fall back to zero.
+func partial(n int) Strategy {
+	return func(v reflect.Value) reflect.Value {
+		if v.Kind() != reflect.String {
+			return reflect.Zero(v.Type())
+		}
+		runes := []rune(v.String())
+		if len(runes) <= n*2 {
+			return reflect.ValueOf(strings.Repeat("*", len(runes))).Convert(v.Type())
+		}
+		masked := string(runes[:n]) + "***" + string(runes[len(runes)-n:])
+		return reflect.ValueOf(masked).Convert(v.Type())
+	}
+}
+
+// hashValue replaces a string field with a stable hex SHA-256 prefix of its
+// value, so operators can correlate log entries without seeing plaintext.
+// Non-string values fall back to zero.
+func hashValue(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.String {
+		return reflect.Zero(v.Type())
+	}
+	sum := sha256.Sum256([]byte(v.String()))
+	return reflect.ValueOf(hex.EncodeToString(sum[:])[:12]).Convert(v.Type())
+}
@@ -0,0 +1,83 @@
+package sentinel
+
+import (
+	"log/slog"
+	"testing"
+)
+
+type strategyExample struct {
+	Email    string `json:"email"`
+	Password string `json:"password" sentinel:"true"`
+	Bio      string `json:"bio,omitempty"`
+	Dashed   string `json:"-"`
+}
+
+func attrsByKey(v slog.Value) map[string]any {
+	out := make(map[string]any)
+	for _, a := range v.Group() {
+		out[a.Key] = a.Value.Any()
+	}
+	return out
+}
+
+// TestReplaceAttrPrefersJSONTagKey checks that attr keys emitted for a
+// partially-redacted struct follow the field's json tag name (including
+// stripping ",omitempty"-style options), not the bare Go field name, and
+// that json:"-" falls back to the Go name rather than being silently
+// renamed to "-".
+func TestReplaceAttrPrefersJSONTagKey(t *testing.T) {
+	data := strategyExample{Email: "a@example.com", Password: "hunter2", Bio: "hi", Dashed: "x"}
+	attr := slog.Attr{Key: "data", Value: slog.AnyValue(data)}
+
+	out := attrsByKey(ReplaceAttr(nil, attr).Value)
+
+	if _, ok := out["email"]; !ok {
+		t.Fatalf("expected key %q, got %v", "email", out)
+	}
+	if _, ok := out["password"]; !ok {
+		t.Fatalf("expected key %q, got %v", "password", out)
+	}
+	if out["password"] != "" {
+		t.Fatalf("password should have been zeroed, got %v", out["password"])
+	}
+	if _, ok := out["bio"]; !ok {
+		t.Fatalf("expected key %q, got %v", "bio", out)
+	}
+	if _, ok := out["Dashed"]; !ok {
+		t.Fatalf("expected json:\"-\" field to keep its Go name, got %v", out)
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	type s struct {
+		Redacted string `sentinel:"redact"`
+		Masked   string `sentinel:"mask"`
+		Partial  string `sentinel:"partial"`
+		Hashed   string `sentinel:"hash"`
+		Dropped  string `sentinel:"drop"`
+	}
+	data := s{
+		Redacted: "a",
+		Masked:   "alice@example.com",
+		Partial:  "alice@example.com",
+		Hashed:   "alice@example.com",
+		Dropped:  "a",
+	}
+	out := attrsByKey(ReplaceAttr(nil, slog.Attr{Key: "data", Value: slog.AnyValue(data)}).Value)
+
+	if out["Redacted"] != "" {
+		t.Fatalf("redact: got %v", out["Redacted"])
+	}
+	if out["Masked"] != "***" {
+		t.Fatalf("mask: got %v", out["Masked"])
+	}
+	if out["Partial"] != "al***om" {
+		t.Fatalf("partial: got %v", out["Partial"])
+	}
+	if out["Hashed"] == data.Hashed || out["Hashed"] == "" {
+		t.Fatalf("hash: got %v", out["Hashed"])
+	}
+	if _, ok := out["Dropped"]; ok {
+		t.Fatalf("drop: field should be omitted, got %v", out)
+	}
+}
@@ -0,0 +1,124 @@
+package sentinel
+
+import "reflect"
+
+// typeDescriptor is a precomputed summary of how a struct type should be
+// processed, so that ReplaceAttr doesn't have to re-walk the struct's fields
+// and re-parse their tags on every log call.
+type typeDescriptor struct {
+	sensitiveFieldIndexes []int      // tagged fields, redacted via strategies
+	strategies            []Strategy // parallel to sensitiveFieldIndexes
+	dropFieldIndexes      []int      // tagged "drop", omitted outright
+	recurseFieldIndexes   []int      // untagged fields that may hold sensitive data
+	isLeafType            bool       // true if nothing below this type needs processing
+}
+
+// descriptorFor returns the cached typeDescriptor for t, building and
+// storing it on first sight. It accounts for the `sentinel` tag (under
+// r.tag), any additional tags configured with WithAdditionalTags, and
+// registered type handlers; field/key matchers are not baked in, since their
+// answer can depend on the path a type is reached through.
+func (r *Redactor) descriptorFor(t reflect.Type) *typeDescriptor {
+	if cached, ok := r.typeCache.Load(t); ok {
+		return cached.(*typeDescriptor)
+	}
+
+	// Store a conservative placeholder before recursing, so that a
+	// self-referential struct (e.g. a linked-list node) doesn't recurse
+	// forever while its own descriptor is still being built. The placeholder
+	// already carries the tag results and marks every other field for
+	// recursion, so a concurrent caller that loads it mid-build still
+	// redacts tagged fields.
+	placeholder := &typeDescriptor{isLeafType: false}
+	for i := 0; i < t.NumField(); i++ {
+		if res, matched := r.resolveTag(t.Field(i)); !matched {
+			placeholder.recurseFieldIndexes = append(placeholder.recurseFieldIndexes, i)
+		} else if res.drop {
+			placeholder.dropFieldIndexes = append(placeholder.dropFieldIndexes, i)
+		} else {
+			placeholder.sensitiveFieldIndexes = append(placeholder.sensitiveFieldIndexes, i)
+			placeholder.strategies = append(placeholder.strategies, res.strategy)
+		}
+	}
+	if actual, loaded := r.typeCache.LoadOrStore(t, placeholder); loaded {
+		return actual.(*typeDescriptor)
+	}
+
+	d := &typeDescriptor{isLeafType: true}
+	if _, handled := r.typeHandlers[t]; handled {
+		d.isLeafType = false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		// Resolve tags before the unexported skip below: a tagged field
+		// must flip isLeafType and land in dropFieldIndexes/
+		// sensitiveFieldIndexes even when it's unexported and
+		// WithUnsafeUnexported is off, or the whole type would be
+		// misclassified as a leaf and processStructCached would hand back
+		// the original struct - tagged field included - untouched. The
+		// runtime loop's existing !field.CanInterface() guard is what
+		// actually keeps such a field from being read without
+		// WithUnsafeUnexported; this only decides whether the type may take
+		// the raw-passthrough shortcut.
+		if res, matched := r.resolveTag(sf); matched {
+			d.isLeafType = false
+			if res.drop {
+				d.dropFieldIndexes = append(d.dropFieldIndexes, i)
+			} else {
+				d.sensitiveFieldIndexes = append(d.sensitiveFieldIndexes, i)
+				d.strategies = append(d.strategies, res.strategy)
+			}
+			continue
+		}
+
+		if sf.PkgPath != "" && !sf.Anonymous && !r.unsafeUnexported {
+			continue // unexported and untagged: never interfaceable, never emitted
+		}
+
+		if r.typeIsLeaf(sf.Type) {
+			continue
+		}
+		d.recurseFieldIndexes = append(d.recurseFieldIndexes, i)
+		d.isLeafType = false
+	}
+
+	r.typeCache.Store(t, d)
+	return d
+}
+
+// typeIsLeaf reports whether values of type t can never contain anything
+// that needs redacting, so processing can skip them entirely. Pointers,
+// interfaces, and maps are treated conservatively as non-leaf, since their
+// concrete contents aren't known statically.
+func (r *Redactor) typeIsLeaf(t reflect.Type) bool {
+	if _, handled := r.typeHandlers[t]; handled {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return r.descriptorFor(t).isLeafType
+	case reflect.Slice, reflect.Array:
+		return r.typeIsLeaf(t.Elem())
+	case reflect.Pointer, reflect.Interface, reflect.Map:
+		return false
+	default:
+		return true
+	}
+}
+
+// sensitiveIndex returns the position of fieldIndex in indexes, or -1 if it
+// isn't present.
+func sensitiveIndex(indexes []int, fieldIndex int) int {
+	for i, idx := range indexes {
+		if idx == fieldIndex {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsIndex(indexes []int, fieldIndex int) bool {
+	return sensitiveIndex(indexes, fieldIndex) >= 0
+}
@@ -0,0 +1,100 @@
+package sentinel
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type cachedLeaf struct {
+	A int
+	B string
+}
+
+type cachedParent struct {
+	Name     string
+	Password string `sentinel:"true"`
+	Leaf     cachedLeaf
+	Leaves   []cachedLeaf
+}
+
+// TestProcessStructCachedRedactsTaggedAndPassesLeaves checks that the
+// matcher-free fast path (processStructCached) still redacts tagged fields
+// correctly and passes untagged leaf fields through untouched.
+func TestProcessStructCachedRedactsTaggedAndPassesLeaves(t *testing.T) {
+	data := cachedParent{
+		Name:     "alice",
+		Password: "hunter2",
+		Leaf:     cachedLeaf{A: 1, B: "x"},
+		Leaves:   []cachedLeaf{{A: 2, B: "y"}},
+	}
+
+	cached := New()
+	cachedOut := attrsByKey(cached.ReplaceAttr(nil, slog.Attr{Key: "data", Value: slog.AnyValue(data)}).Value)
+
+	if cachedOut["Password"] != "" {
+		t.Fatalf("Password: got %v, want zeroed", cachedOut["Password"])
+	}
+	if cachedOut["Name"] != "alice" {
+		t.Fatalf("Name: got %v, want alice", cachedOut["Name"])
+	}
+
+	leaf, ok := cachedOut["Leaf"].(cachedLeaf)
+	if !ok {
+		t.Fatalf("Leaf: got %#v (%T), want cachedLeaf copied through as a leaf", cachedOut["Leaf"], cachedOut["Leaf"])
+	}
+	if leaf != data.Leaf {
+		t.Fatalf("Leaf: got %#v, want %#v", leaf, data.Leaf)
+	}
+}
+
+// TestTypeIsLeafShortCircuitsSliceOfLeaves checks that a slice whose element
+// type holds nothing sensitive is left untouched (same backing data),
+// confirming the leaf short-circuit in processSliceOrArray actually skips
+// the copy-and-reflect loop instead of just happening to produce an equal
+// copy.
+func TestTypeIsLeafShortCircuitsSliceOfLeaves(t *testing.T) {
+	r := New()
+	leaves := []cachedLeaf{{A: 1, B: "x"}, {A: 2, B: "y"}}
+
+	out := r.ReplaceAttr(nil, slog.Attr{Key: "leaves", Value: slog.AnyValue(leaves)})
+
+	got, ok := out.Value.Any().([]cachedLeaf)
+	if !ok {
+		t.Fatalf("got %#v (%T), want []cachedLeaf", out.Value.Any(), out.Value.Any())
+	}
+	if &got[0] != &leaves[0] {
+		t.Fatalf("expected leaf-short-circuit to return the original backing array untouched")
+	}
+}
+
+type unexportedTaggedLeaf struct {
+	secret string `sentinel:"true"`
+	Public string
+}
+
+// TestDescriptorForUnexportedTaggedFieldIsNotLeaf checks that a tagged but
+// unexported field keeps its struct type out of the isLeafType
+// fast-passthrough path, even with WithUnsafeUnexported left off. Without
+// that, descriptorFor would never see the tag (the unexported skip ran
+// first), the whole type would be cached as a leaf, and
+// processStructCached would hand back the original struct - unexported
+// secret included - verbatim via %v-style formatting.
+func TestDescriptorForUnexportedTaggedFieldIsNotLeaf(t *testing.T) {
+	r := New()
+	data := unexportedTaggedLeaf{secret: "TOPSECRET", Public: "ok"}
+
+	out := r.ReplaceAttr(nil, slog.Attr{Key: "data", Value: slog.AnyValue(data)})
+
+	rendered := fmt.Sprintf("%v", out.Value.Any())
+	if strings.Contains(rendered, "TOPSECRET") {
+		t.Fatalf("secret leaked through: %s", rendered)
+	}
+	if out.Value.Kind() != slog.KindGroup {
+		t.Fatalf("expected type to be processed as non-leaf (KindGroup), got %v raw value %#v", out.Value.Kind(), out.Value.Any())
+	}
+	if got := attrsByKey(out.Value)["Public"]; got != "ok" {
+		t.Fatalf("Public: got %v, want ok", got)
+	}
+}
@@ -0,0 +1,19 @@
+package sentinel
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// unexportedValue returns an interfaceable, settable accessor for an
+// unexported struct field when WithUnsafeUnexported is set and the field is
+// addressable. Without that opt-in, or when field isn't addressable (e.g. it
+// came from a struct passed by value rather than through a pointer), ok is
+// false and the field must be left untouched, matching the field's prior
+// silent omission.
+func (r *Redactor) unexportedValue(field reflect.Value) (_ reflect.Value, ok bool) {
+	if !r.unsafeUnexported || !field.CanAddr() {
+		return reflect.Value{}, false
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(), true
+}